@@ -2,27 +2,61 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
-	"math"
 	"net/http"
+	"net/url"
 	"os"
-	"strconv"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
 )
 
 // ApiClient provides a Go client for API access with support for authentication,
 // request handling, and response parsing.
 type ApiClient struct {
-	ApiKey      string
-	BaseURL     string
-	HttpClient  *http.Client
-	MaxRetries  int
-	RetryDelay  time.Duration
-	RetryBackoff float64
-	Headers     map[string]string
+	ApiKey     string
+	BaseURL    string
+	HttpClient *http.Client
+	Headers    map[string]string
+
+	// RetryPolicy controls backoff timing between retries.
+	RetryPolicy RetryPolicy
+	// RetryNonIdempotent allows retrying verbs other than GET/HEAD/PUT/DELETE/OPTIONS.
+	RetryNonIdempotent bool
+
+	// Limiter is consulted before every outbound request.
+	Limiter *rate.Limiter
+
+	// Authenticator signs every outgoing request, including retries, so a
+	// refreshed token is always used. Install one with SetAuthenticator or
+	// WithAuthenticator; for the common Bearer token case, NewApiClient
+	// installs one from WithAPIKey or API_KEY, and SetApiKey replaces it.
+	Authenticator Authenticator
+
+	// Logger receives diagnostic output in place of fmt.Printf.
+	Logger Logger
+
+	// TracerProvider and MeterProvider configure the OTEL span and
+	// apiclient.request.* instruments created for every outbound request. If
+	// unset, the global OTEL providers are used.
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+
+	// RequestHook and ResponseHook let callers observe every attempt without
+	// depending on OTEL.
+	RequestHook  RequestHook
+	ResponseHook ResponseHook
+
+	retryDecisions      map[int]bool
+	transportMiddleware []func(http.RoundTripper) http.RoundTripper
+	instr               *instruments
 }
 
 // ApiError represents an error returned by the API
@@ -37,252 +71,393 @@ func (e *ApiError) Error() string {
 	return fmt.Sprintf("API Error %d: %s", e.Code, e.Message)
 }
 
-// NewApiClient creates a new API client with the given configuration
-func NewApiClient(apiKey string, baseURL string, config map[string]interface{}) *ApiClient {
-	// Use environment variables as fallbacks
-	if apiKey == "" {
-		apiKey = os.Getenv("API_KEY")
+// NewApiClient creates a new API client, applying opts on top of the defaults
+// (30s HTTP timeout, DefaultRetryPolicy, 4 rps rate limit). ApiKey and BaseURL
+// fall back to the API_KEY and API_BASE_URL environment variables when not set
+// via WithAPIKey/WithBaseURL.
+func NewApiClient(opts ...Option) (*ApiClient, error) {
+	c := &ApiClient{
+		HttpClient: &http.Client{Timeout: 30 * time.Second},
+		Headers: map[string]string{
+			"Content-Type": "application/json",
+			"Accept":       "application/json",
+			"User-Agent":   "Go-ApiClient/1.0",
+		},
+		RetryPolicy:    DefaultRetryPolicy,
+		Limiter:        rate.NewLimiter(rate.Limit(4), 4),
+		Logger:         noopLogger{},
+		retryDecisions: map[int]bool{},
 	}
-	
-	if baseURL == "" {
-		baseURL = os.Getenv("API_BASE_URL")
-		if baseURL == "" {
-			baseURL = "https://api.example.com/v1"
-		}
+
+	for _, opt := range opts {
+		opt(c)
 	}
-	
-	// Extract configuration values with defaults
-	timeout := 30
-	maxRetries := 3
-	retryDelay := 1
-	retryBackoff := 2.0
-	
-	if config != nil {
-		if t, ok := config["timeout"].(int); ok {
-			timeout = t
-		}
-		if r, ok := config["maxRetries"].(int); ok {
-			maxRetries = r
-		}
-		if d, ok := config["retryDelay"].(int); ok {
-			retryDelay = d
-		}
-		if b, ok := config["retryBackoff"].(float64); ok {
-			retryBackoff = b
-		}
+
+	if c.ApiKey == "" {
+		c.ApiKey = os.Getenv("API_KEY")
 	}
-	
-	// Initialize HTTP client with timeout
-	httpClient := &http.Client{
-		Timeout: time.Duration(timeout) * time.Second,
-	}
-	
-	// Set default headers
-	headers := map[string]string{
-		"Content-Type": "application/json",
-		"Accept":       "application/json",
-		"User-Agent":   "Go-ApiClient/1.0",
-	}
-	
-	// Set API key if provided
-	if apiKey != "" {
-		headers["Authorization"] = "Bearer " + apiKey
-	}
-	
-	client := &ApiClient{
-		ApiKey:       apiKey,
-		BaseURL:      baseURL,
-		HttpClient:   httpClient,
-		MaxRetries:   maxRetries,
-		RetryDelay:   time.Duration(retryDelay) * time.Second,
-		RetryBackoff: retryBackoff,
-		Headers:      headers,
-	}
-	
-	fmt.Printf("Initialized API client with base URL: %s\n", baseURL)
-	
-	return client
-}
-
-// Request sends a request to the API with retry logic
-func (c *ApiClient) Request(method string, endpoint string, params map[string]interface{}, 
-                           data map[string]interface{}, headers map[string]string) (map[string]interface{}, error) {
-	url := c.BaseURL + "/" + trimPrefix(endpoint, "/")
-	
-	// Add query parameters to URL if provided
-	if params != nil && len(params) > 0 {
-		url += "?"
-		i := 0
-		for k, v := range params {
-			if i > 0 {
-				url += "&"
-			}
-			url += fmt.Sprintf("%s=%v", k, v)
-			i++
+
+	if c.BaseURL == "" {
+		c.BaseURL = os.Getenv("API_BASE_URL")
+		if c.BaseURL == "" {
+			c.BaseURL = "https://api.example.com/v1"
 		}
 	}
-	
-	// Prepare request body
-	var reqBody io.Reader
-	if data != nil {
-		jsonData, err := json.Marshal(data)
-		if err != nil {
-			return nil, fmt.Errorf("error marshaling request data: %w", err)
-		}
-		reqBody = bytes.NewBuffer(jsonData)
+
+	if c.Authenticator == nil && c.ApiKey != "" {
+		c.Authenticator = BearerToken{Token: c.ApiKey}
+	}
+
+	transport := c.HttpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(c.transportMiddleware) - 1; i >= 0; i-- {
+		transport = c.transportMiddleware[i](transport)
 	}
-	
-	// Create request
-	req, err := http.NewRequest(method, url, reqBody)
+	c.HttpClient.Transport = transport
+
+	instr, err := newInstruments(c.TracerProvider, c.MeterProvider)
 	if err != nil {
-		return nil, fmt.Errorf("error creating request: %w", err)
+		return nil, fmt.Errorf("initializing instrumentation: %w", err)
+	}
+	c.instr = instr
+
+	c.Logger.Printf("initialized API client with base URL: %s", c.BaseURL)
+
+	return c, nil
+}
+
+// sleep blocks for d, returning ctx.Err() early if ctx is cancelled first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// do executes method/endpoint against the API, applying rate limiting and
+// retrying according to c.RetryPolicy, and returns the final raw HTTP
+// response together with its fully-read body. It does not itself treat a
+// 4xx/5xx status as an error: callers decode the body and inspect
+// resp.StatusCode to build a response-shape-appropriate error. A cancelled
+// or expired ctx aborts backoff immediately instead of sleeping it out.
+//
+// Every call is wrapped in a "HTTP {method} {route}" span, where route is
+// routeTemplate(endpoint), propagated to
+// the server via a W3C traceparent header, and recorded against the
+// apiclient.request.* instruments; see RequestHook/ResponseHook for a
+// non-OTEL alternative.
+func (c *ApiClient) do(ctx context.Context, method string, endpoint string, params url.Values,
+	data interface{}, headers map[string]string) (resp *http.Response, body []byte, err error) {
+	start := time.Now()
+	route := routeTemplate(endpoint)
+	ctx, span := c.instr.tracer.Start(ctx, fmt.Sprintf("HTTP %s %s", method, route), trace.WithSpanKind(trace.SpanKindClient))
+	attempt := 0
+	lastStatus := 0
+	defer func() {
+		statusCode := lastStatus
+
+		span.SetAttributes(spanAttributes(method, route, endpoint, statusCode, attempt, len(body))...)
+		statusCodeField, desc := spanStatusFor(statusCode, err)
+		span.SetStatus(statusCodeField, desc)
+		span.End()
+
+		metricAttrs := metric.WithAttributes(
+			attribute.String("endpoint", route),
+			attribute.String("status_class", statusClass(statusCode)),
+		)
+		c.instr.requestDuration.Record(ctx, time.Since(start).Seconds(), metricAttrs)
+		if err != nil || statusCode >= 400 {
+			c.instr.requestErrors.Add(ctx, 1, metricAttrs)
+		}
+	}()
+
+	reqURL := c.BaseURL + "/" + trimPrefix(endpoint, "/")
+
+	// Add query parameters to the URL, sorted by key, with proper escaping.
+	if len(params) > 0 {
+		reqURL += "?" + params.Encode()
 	}
-	
-	// Set headers
-	for k, v := range c.Headers {
-		req.Header.Set(k, v)
+
+	// Marshal the request body once; a fresh io.Reader over it is attached
+	// to a brand new *http.Request on every attempt below, since the body
+	// reader of a previous attempt is drained by HttpClient.Do and can't be
+	// replayed on retry.
+	var jsonData []byte
+	if data != nil {
+		var marshalErr error
+		jsonData, marshalErr = json.Marshal(data)
+		if marshalErr != nil {
+			err = fmt.Errorf("error marshaling request data: %w", marshalErr)
+			return nil, nil, err
+		}
 	}
-	
-	if headers != nil {
+
+	canRetry := c.RetryNonIdempotent || idempotentMethods[method]
+
+	// serverWait, when set, replaces the policy backoff before the next
+	// attempt with the wait a 429 response asked for.
+	var serverWait time.Duration
+	haveServerWait := false
+
+	for ; attempt <= c.RetryPolicy.MaxRetries; attempt++ {
+		if attempt > 0 {
+			delay := c.RetryPolicy.delay(attempt)
+			if haveServerWait {
+				delay, haveServerWait = serverWait, false
+			}
+			c.Logger.Printf("retrying %s %s in %v (attempt %d/%d)", method, endpoint, delay, attempt, c.RetryPolicy.MaxRetries)
+			c.instr.requestRetries.Add(ctx, 1, metric.WithAttributes(
+				attribute.String("endpoint", route),
+				attribute.String("status_class", statusClass(lastStatus)),
+			))
+			if sleepErr := sleep(ctx, delay); sleepErr != nil {
+				err = sleepErr
+				return nil, nil, err
+			}
+		}
+
+		if waitErr := c.Limiter.Wait(ctx); waitErr != nil {
+			err = fmt.Errorf("rate limiter: %w", waitErr)
+			return nil, nil, err
+		}
+
+		var reqBody io.Reader
+		if jsonData != nil {
+			reqBody = bytes.NewReader(jsonData)
+		}
+
+		req, reqErr := http.NewRequestWithContext(ctx, method, reqURL, reqBody)
+		if reqErr != nil {
+			err = fmt.Errorf("error creating request: %w", reqErr)
+			return nil, nil, err
+		}
+
+		for k, v := range c.Headers {
+			req.Header.Set(k, v)
+		}
 		for k, v := range headers {
 			req.Header.Set(k, v)
 		}
-	}
-	
-	// Retry logic
-	var resp *http.Response
-	var responseData map[string]interface{}
-	
-	for retries := 0; retries <= c.MaxRetries; retries++ {
-		if retries > 0 {
-			delay := time.Duration(float64(c.RetryDelay) * math.Pow(c.RetryBackoff, float64(retries-1)))
-			fmt.Printf("Retrying request in %v...\n", delay)
-			time.Sleep(delay)
+		injectTraceContext(ctx, req)
+
+		if c.Authenticator != nil {
+			if authErr := c.Authenticator.Apply(req); authErr != nil {
+				err = fmt.Errorf("applying authenticator: %w", authErr)
+				return nil, nil, err
+			}
+		}
+
+		if c.RequestHook != nil {
+			c.RequestHook(ctx, req)
 		}
-		
+
 		resp, err = c.HttpClient.Do(req)
+		if c.ResponseHook != nil {
+			c.ResponseHook(ctx, resp, err)
+		}
 		if err != nil {
-			if retries == c.MaxRetries {
-				return nil, fmt.Errorf("connection failed after %d retries: %w", c.MaxRetries, err)
+			if !canRetry || attempt == c.RetryPolicy.MaxRetries {
+				err = fmt.Errorf("connection failed after %d retries: %w", attempt, err)
+				return nil, nil, err
 			}
-			fmt.Printf("Connection error: %s\n", err.Error())
+			c.Logger.Printf("connection error: %s", err.Error())
 			continue
 		}
-		
+		lastStatus = resp.StatusCode
+
 		// Check for rate limiting
-		if resp.StatusCode == 429 {
-			retryAfter := c.RetryDelay
-			if s := resp.Header.Get("Retry-After"); s != "" {
-				if seconds, err := strconv.Atoi(s); err == nil {
-					retryAfter = time.Duration(seconds) * time.Second
-				}
-			}
-			fmt.Printf("Rate limited. Retrying after %v.\n", retryAfter)
+		if resp.StatusCode == http.StatusTooManyRequests && c.shouldRetry(http.StatusTooManyRequests) && canRetry && attempt < c.RetryPolicy.MaxRetries {
 			resp.Body.Close()
-			time.Sleep(retryAfter)
+			wait := c.RetryPolicy.delay(attempt + 1)
+			if d, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+				wait = d
+			}
+			c.Logger.Printf("rate limited by server. Retrying after %v.", wait)
+			serverWait, haveServerWait = wait, true
 			continue
 		}
-		
-		// Read and parse response body
+
+		// Read response body
 		defer resp.Body.Close()
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			if retries == c.MaxRetries {
-				return nil, fmt.Errorf("error reading response body: %w", err)
+		respBody, readErr := io.ReadAll(resp.Body)
+		if readErr != nil {
+			if !canRetry || attempt == c.RetryPolicy.MaxRetries {
+				err = fmt.Errorf("error reading response body: %w", readErr)
+				return nil, nil, err
 			}
-			fmt.Printf("Error reading response body: %s\n", err.Error())
+			c.Logger.Printf("error reading response body: %s", readErr.Error())
 			continue
 		}
-		
-		// Parse JSON response
-		if len(body) > 0 {
-			err = json.Unmarshal(body, &responseData)
-			if err != nil {
-				responseData = map[string]interface{}{
-					"raw_content": string(body),
-				}
-			}
-		} else {
-			responseData = make(map[string]interface{})
+		body = respBody
+
+		// Retryable error status, can retry
+		if resp.StatusCode >= 400 && c.shouldRetry(resp.StatusCode) && canRetry && attempt < c.RetryPolicy.MaxRetries {
+			c.Logger.Printf("server error: status %d. Retrying...", resp.StatusCode)
+			continue
 		}
-		
-		// Check for errors
-		if resp.StatusCode >= 400 {
-			errorMessage := "Unknown error"
-			errorCode := resp.StatusCode
-			
-			if errorData, ok := responseData["error"].(map[string]interface{}); ok {
-				if msg, ok := errorData["message"].(string); ok {
-					errorMessage = msg
-				}
-				if code, ok := errorData["code"].(float64); ok {
-					errorCode = int(code)
-				}
+
+		return resp, body, nil
+	}
+
+	// This should never happen, but just in case
+	err = errors.New("unexpected error in request handling")
+	return nil, nil, err
+}
+
+// Request sends a request to the API, applying rate limiting and retrying
+// according to c.RetryPolicy.
+//
+// Deprecated: Request and the untyped Get/Post/Put/Delete/Patch methods are
+// kept as a shim for one release. Prefer Do, which decodes into a concrete
+// type instead of map[string]interface{}.
+func (c *ApiClient) Request(method string, endpoint string, params map[string]interface{},
+	data map[string]interface{}, headers map[string]string) (map[string]interface{}, error) {
+	return c.RequestCtx(context.Background(), method, endpoint, params, data, headers)
+}
+
+// RequestCtx is the context-aware variant of Request. A cancelled or
+// deadline-exceeded ctx aborts in-flight retries immediately instead of
+// sleeping out the backoff.
+func (c *ApiClient) RequestCtx(ctx context.Context, method string, endpoint string, params map[string]interface{},
+	data map[string]interface{}, headers map[string]string) (map[string]interface{}, error) {
+	resp, body, err := c.do(ctx, method, endpoint, paramsFromMap(params), data, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	var responseData map[string]interface{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &responseData); err != nil {
+			responseData = map[string]interface{}{
+				"raw_content": string(body),
 			}
-			
-			// Server error, can retry
-			if resp.StatusCode >= 500 && retries < c.MaxRetries {
-				fmt.Printf("Server error: %s. Retrying...\n", errorMessage)
-				continue
+		}
+	} else {
+		responseData = make(map[string]interface{})
+	}
+
+	if resp.StatusCode >= 400 {
+		errorMessage := "Unknown error"
+		errorCode := resp.StatusCode
+
+		if errorData, ok := responseData["error"].(map[string]interface{}); ok {
+			if msg, ok := errorData["message"].(string); ok {
+				errorMessage = msg
 			}
-			
-			// Client error or max retries reached
-			return nil, &ApiError{
-				Message:  errorMessage,
-				Code:     errorCode,
-				Response: responseData,
+			if code, ok := errorData["code"].(float64); ok {
+				errorCode = int(code)
 			}
 		}
-		
-		// Success
-		return responseData, nil
+
+		return nil, &ApiError{
+			Message:  errorMessage,
+			Code:     errorCode,
+			Response: responseData,
+		}
 	}
-	
-	// This should never happen, but just in case
-	return nil, errors.New("unexpected error in request handling")
+
+	return responseData, nil
+}
+
+// shouldRetry reports whether a response with the given status code should be
+// retried, honoring any per-status overrides registered via WithRetryDecision.
+func (c *ApiClient) shouldRetry(status int) bool {
+	if decision, ok := c.retryDecisions[status]; ok {
+		return decision
+	}
+	return shouldRetryStatus(status)
 }
 
 // Get sends a GET request to the API
+//
+// Deprecated: prefer Do[T] for a typed response.
 func (c *ApiClient) Get(endpoint string, params map[string]interface{}) (map[string]interface{}, error) {
 	return c.Request("GET", endpoint, params, nil, nil)
 }
 
 // Post sends a POST request to the API
+//
+// Deprecated: prefer Do[T] for a typed response.
 func (c *ApiClient) Post(endpoint string, data map[string]interface{}) (map[string]interface{}, error) {
 	return c.Request("POST", endpoint, nil, data, nil)
 }
 
 // PostWithParams sends a POST request to the API with query parameters
+//
+// Deprecated: prefer Do[T] for a typed response.
 func (c *ApiClient) PostWithParams(endpoint string, data map[string]interface{}, params map[string]interface{}) (map[string]interface{}, error) {
 	return c.Request("POST", endpoint, params, data, nil)
 }
 
 // Put sends a PUT request to the API
+//
+// Deprecated: prefer Do[T] for a typed response.
 func (c *ApiClient) Put(endpoint string, data map[string]interface{}) (map[string]interface{}, error) {
 	return c.Request("PUT", endpoint, nil, data, nil)
 }
 
 // Delete sends a DELETE request to the API
+//
+// Deprecated: prefer Do[T] for a typed response.
 func (c *ApiClient) Delete(endpoint string) (map[string]interface{}, error) {
 	return c.Request("DELETE", endpoint, nil, nil, nil)
 }
 
 // Patch sends a PATCH request to the API
+//
+// Deprecated: prefer Do[T] for a typed response.
 func (c *ApiClient) Patch(endpoint string, data map[string]interface{}) (map[string]interface{}, error) {
 	return c.Request("PATCH", endpoint, nil, data, nil)
 }
 
-// SetApiKey sets the API key for authentication
+// GetCtx is the context-aware variant of Get.
+func (c *ApiClient) GetCtx(ctx context.Context, endpoint string, params map[string]interface{}) (map[string]interface{}, error) {
+	return c.RequestCtx(ctx, "GET", endpoint, params, nil, nil)
+}
+
+// PostCtx is the context-aware variant of Post.
+func (c *ApiClient) PostCtx(ctx context.Context, endpoint string, data map[string]interface{}) (map[string]interface{}, error) {
+	return c.RequestCtx(ctx, "POST", endpoint, nil, data, nil)
+}
+
+// PostWithParamsCtx is the context-aware variant of PostWithParams.
+func (c *ApiClient) PostWithParamsCtx(ctx context.Context, endpoint string, data map[string]interface{}, params map[string]interface{}) (map[string]interface{}, error) {
+	return c.RequestCtx(ctx, "POST", endpoint, params, data, nil)
+}
+
+// PutCtx is the context-aware variant of Put.
+func (c *ApiClient) PutCtx(ctx context.Context, endpoint string, data map[string]interface{}) (map[string]interface{}, error) {
+	return c.RequestCtx(ctx, "PUT", endpoint, nil, data, nil)
+}
+
+// DeleteCtx is the context-aware variant of Delete.
+func (c *ApiClient) DeleteCtx(ctx context.Context, endpoint string) (map[string]interface{}, error) {
+	return c.RequestCtx(ctx, "DELETE", endpoint, nil, nil, nil)
+}
+
+// PatchCtx is the context-aware variant of Patch.
+func (c *ApiClient) PatchCtx(ctx context.Context, endpoint string, data map[string]interface{}) (map[string]interface{}, error) {
+	return c.RequestCtx(ctx, "PATCH", endpoint, nil, data, nil)
+}
+
+// SetApiKey sets the API key for authentication, installing a BearerToken
+// authenticator.
 func (c *ApiClient) SetApiKey(apiKey string) {
 	c.ApiKey = apiKey
-	c.Headers["Authorization"] = "Bearer " + apiKey
-	fmt.Println("Updated API key")
+	c.SetAuthenticator(BearerToken{Token: apiKey})
+	c.Logger.Printf("updated API key")
 }
 
 // SetBaseURL sets the base URL for API requests
 func (c *ApiClient) SetBaseURL(baseURL string) {
 	c.BaseURL = baseURL
-	fmt.Printf("Updated base URL: %s\n", baseURL)
+	c.Logger.Printf("updated base URL: %s", baseURL)
 }
 
 // Helper function to trim prefix from a string
@@ -316,36 +491,78 @@ func (r *Resource) GetEndpoint(path string) string {
 }
 
 // List retrieves a list of resources
+//
+// Deprecated: prefer the package-level List[T] for a typed, auto-paginating iterator.
 func (r *Resource) List(params map[string]interface{}) (map[string]interface{}, error) {
-	return r.Client.Get(r.GetEndpoint(""), params)
+	return r.ListCtx(context.Background(), params)
+}
+
+// ListCtx is the context-aware variant of List.
+func (r *Resource) ListCtx(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	return r.Client.GetCtx(ctx, r.GetEndpoint(""), params)
 }
 
 // Get retrieves a resource by ID
+//
+// Deprecated: prefer Do[T] for a typed response.
 func (r *Resource) Get(resourceID string, params map[string]interface{}) (map[string]interface{}, error) {
-	return r.Client.Get(r.GetEndpoint(resourceID), params)
+	return r.GetCtx(context.Background(), resourceID, params)
+}
+
+// GetCtx is the context-aware variant of Get.
+func (r *Resource) GetCtx(ctx context.Context, resourceID string, params map[string]interface{}) (map[string]interface{}, error) {
+	return r.Client.GetCtx(ctx, r.GetEndpoint(url.PathEscape(resourceID)), params)
 }
 
 // Create creates a new resource
+//
+// Deprecated: prefer Do[T] for a typed response.
 func (r *Resource) Create(data map[string]interface{}, params map[string]interface{}) (map[string]interface{}, error) {
+	return r.CreateCtx(context.Background(), data, params)
+}
+
+// CreateCtx is the context-aware variant of Create.
+func (r *Resource) CreateCtx(ctx context.Context, data map[string]interface{}, params map[string]interface{}) (map[string]interface{}, error) {
 	if params != nil {
-		return r.Client.PostWithParams(r.GetEndpoint(""), data, params)
+		return r.Client.PostWithParamsCtx(ctx, r.GetEndpoint(""), data, params)
 	}
-	return r.Client.Post(r.GetEndpoint(""), data)
+	return r.Client.PostCtx(ctx, r.GetEndpoint(""), data)
 }
 
 // Update updates a resource
+//
+// Deprecated: prefer Do[T] for a typed response.
 func (r *Resource) Update(resourceID string, data map[string]interface{}) (map[string]interface{}, error) {
-	return r.Client.Put(r.GetEndpoint(resourceID), data)
+	return r.UpdateCtx(context.Background(), resourceID, data)
+}
+
+// UpdateCtx is the context-aware variant of Update.
+func (r *Resource) UpdateCtx(ctx context.Context, resourceID string, data map[string]interface{}) (map[string]interface{}, error) {
+	return r.Client.PutCtx(ctx, r.GetEndpoint(url.PathEscape(resourceID)), data)
 }
 
 // Delete deletes a resource
+//
+// Deprecated: prefer Do[T] for a typed response.
 func (r *Resource) Delete(resourceID string) (map[string]interface{}, error) {
-	return r.Client.Delete(r.GetEndpoint(resourceID))
+	return r.DeleteCtx(context.Background(), resourceID)
+}
+
+// DeleteCtx is the context-aware variant of Delete.
+func (r *Resource) DeleteCtx(ctx context.Context, resourceID string) (map[string]interface{}, error) {
+	return r.Client.DeleteCtx(ctx, r.GetEndpoint(url.PathEscape(resourceID)))
 }
 
 // Patch partially updates a resource
+//
+// Deprecated: prefer Do[T] for a typed response.
 func (r *Resource) Patch(resourceID string, data map[string]interface{}) (map[string]interface{}, error) {
-	return r.Client.Patch(r.GetEndpoint(resourceID), data)
+	return r.PatchCtx(context.Background(), resourceID, data)
+}
+
+// PatchCtx is the context-aware variant of Patch.
+func (r *Resource) PatchCtx(ctx context.Context, resourceID string, data map[string]interface{}) (map[string]interface{}, error) {
+	return r.Client.PatchCtx(ctx, r.GetEndpoint(url.PathEscape(resourceID)), data)
 }
 
 // Helper function to trim suffix from a string
@@ -369,11 +586,15 @@ func NewAccessTierResource(client *ApiClient) *AccessTierResource {
 }
 
 // GetCurrent retrieves the current user's access tier
+//
+// Deprecated: prefer GetCurrentTyped.
 func (r *AccessTierResource) GetCurrent() (map[string]interface{}, error) {
 	return r.Client.Get(r.GetEndpoint("current"), nil)
 }
 
 // RequestUpgrade requests an upgrade to a new tier
+//
+// Deprecated: prefer RequestUpgradeTyped.
 func (r *AccessTierResource) RequestUpgrade(tierID string) (map[string]interface{}, error) {
 	data := map[string]interface{}{
 		"tier_id": tierID,
@@ -382,11 +603,15 @@ func (r *AccessTierResource) RequestUpgrade(tierID string) (map[string]interface
 }
 
 // GetUpgradeStatus retrieves the status of a tier upgrade request
+//
+// Deprecated: prefer GetUpgradeStatusTyped.
 func (r *AccessTierResource) GetUpgradeStatus(requestID string) (map[string]interface{}, error) {
-	return r.Client.Get(r.GetEndpoint("upgrade-status/"+requestID), nil)
+	return r.Client.Get(r.GetEndpoint("upgrade-status")+"/"+url.PathEscape(requestID), nil)
 }
 
 // GetQuotaUsage retrieves the current user's quota usage
+//
+// Deprecated: prefer GetQuotaUsageTyped.
 func (r *AccessTierResource) GetQuotaUsage() (map[string]interface{}, error) {
 	return r.Client.Get(r.GetEndpoint("quota-usage"), nil)
 }
@@ -404,26 +629,32 @@ func NewApiKeyResource(client *ApiClient) *ApiKeyResource {
 }
 
 // CreateKey creates a new API key
+//
+// Deprecated: prefer CreateKeyTyped.
 func (r *ApiKeyResource) CreateKey(name string, expiryDays int) (map[string]interface{}, error) {
 	data := make(map[string]interface{})
-	
+
 	if name != "" {
 		data["name"] = name
 	}
-	
+
 	if expiryDays > 0 {
 		data["expiry_days"] = expiryDays
 	}
-	
+
 	return r.Client.Post(r.GetEndpoint(""), data)
 }
 
 // RevokeKey revokes an API key
+//
+// Deprecated: prefer RevokeKeyTyped.
 func (r *ApiKeyResource) RevokeKey(keyID string) (map[string]interface{}, error) {
-	return r.Client.Delete(r.GetEndpoint(keyID))
+	return r.Client.Delete(r.GetEndpoint(url.PathEscape(keyID)))
 }
 
 // GetCurrent retrieves the current API key information
+//
+// Deprecated: prefer GetCurrentTyped.
 func (r *ApiKeyResource) GetCurrent() (map[string]interface{}, error) {
 	return r.Client.Get(r.GetEndpoint("current"), nil)
 }
@@ -441,21 +672,29 @@ func NewUserResource(client *ApiClient) *UserResource {
 }
 
 // GetProfile retrieves the current user's profile
+//
+// Deprecated: prefer GetProfileTyped.
 func (r *UserResource) GetProfile() (map[string]interface{}, error) {
 	return r.Client.Get(r.GetEndpoint("profile"), nil)
 }
 
 // UpdateProfile updates the current user's profile
+//
+// Deprecated: prefer UpdateProfileTyped.
 func (r *UserResource) UpdateProfile(data map[string]interface{}) (map[string]interface{}, error) {
 	return r.Client.Put(r.GetEndpoint("profile"), data)
 }
 
 // GetPreferences retrieves the current user's preferences
+//
+// Deprecated: prefer GetPreferencesTyped.
 func (r *UserResource) GetPreferences() (map[string]interface{}, error) {
 	return r.Client.Get(r.GetEndpoint("preferences"), nil)
 }
 
 // UpdatePreferences updates the current user's preferences
+//
+// Deprecated: prefer UpdatePreferencesTyped.
 func (r *UserResource) UpdatePreferences(data map[string]interface{}) (map[string]interface{}, error) {
 	return r.Client.Put(r.GetEndpoint("preferences"), data)
 }
@@ -473,42 +712,62 @@ func NewSandboxResource(client *ApiClient) *SandboxResource {
 }
 
 // CreateEnvironment creates a new sandbox environment
+//
+// Deprecated: prefer CreateEnvironmentTyped.
 func (r *SandboxResource) CreateEnvironment(name string, template string) (map[string]interface{}, error) {
 	data := make(map[string]interface{})
-	
+
 	if name != "" {
 		data["name"] = name
 	}
-	
+
 	if template != "" {
 		data["template"] = template
 	}
-	
+
 	return r.Client.Post(r.GetEndpoint("environments"), data)
 }
 
 // GetEnvironment retrieves a sandbox environment
+//
+// Deprecated: prefer GetEnvironmentTyped.
 func (r *SandboxResource) GetEnvironment(environmentID string) (map[string]interface{}, error) {
-	return r.Client.Get(r.GetEndpoint("environments/"+environmentID), nil)
+	return r.Client.Get(r.GetEndpoint("environments")+"/"+url.PathEscape(environmentID), nil)
 }
 
 // DeleteEnvironment deletes a sandbox environment
+//
+// Deprecated: prefer DeleteEnvironmentTyped.
 func (r *SandboxResource) DeleteEnvironment(environmentID string) (map[string]interface{}, error) {
-	return r.Client.Delete(r.GetEndpoint("environments/" + environmentID))
+	return r.Client.Delete(r.GetEndpoint("environments") + "/" + url.PathEscape(environmentID))
 }
 
 // ExecuteRequest executes a request in the sandbox environment
+//
+// Deprecated: prefer ExecuteRequestTyped.
 func (r *SandboxResource) ExecuteRequest(environmentID string, endpoint string, method string, data map[string]interface{}) (map[string]interface{}, error) {
 	requestData := map[string]interface{}{
 		"endpoint": endpoint,
 		"method":   method,
 	}
-	
+
 	if data != nil {
 		requestData["data"] = data
 	}
-	
-	return r.Client.Post(r.GetEndpoint("environments/"+environmentID+"/execute"), requestData)
+
+	return r.Client.Post(r.GetEndpoint("environments")+"/"+url.PathEscape(environmentID)+"/execute", requestData)
+}
+
+// DownloadArtifact downloads a sandbox environment's artifact into w using
+// parallel ranged GETs; see ApiClient.DownloadToWriterAt.
+func (r *SandboxResource) DownloadArtifact(ctx context.Context, environmentID string, w io.WriterAt, opts DownloadOptions) error {
+	return r.Client.DownloadToWriterAt(ctx, r.GetEndpoint("environments")+"/"+url.PathEscape(environmentID)+"/artifact", w, opts)
+}
+
+// UploadArtifact uploads a sandbox environment's artifact from data; see
+// ApiClient.UploadFromReader.
+func (r *SandboxResource) UploadArtifact(ctx context.Context, environmentID string, data io.Reader, opts UploadOptions) error {
+	return r.Client.UploadFromReader(ctx, r.GetEndpoint("environments")+"/"+url.PathEscape(environmentID)+"/artifact", data, opts)
 }
 
 // FeedbackResource provides access to the feedback API endpoints
@@ -524,51 +783,60 @@ func NewFeedbackResource(client *ApiClient) *FeedbackResource {
 }
 
 // Submit submits feedback
+//
+// Deprecated: prefer SubmitTyped.
 func (r *FeedbackResource) Submit(feedbackType string, content string, metadata map[string]interface{}) (map[string]interface{}, error) {
 	data := map[string]interface{}{
 		"type":    feedbackType,
 		"content": content,
 	}
-	
+
 	if metadata != nil {
 		data["metadata"] = metadata
 	}
-	
+
 	return r.Client.Post(r.GetEndpoint(""), data)
 }
 
 // GetSubmissions retrieves the current user's feedback submissions
+//
+// Deprecated: prefer GetSubmissionsTyped.
 func (r *FeedbackResource) GetSubmissions() (map[string]interface{}, error) {
 	return r.Client.Get(r.GetEndpoint("submissions"), nil)
 }
 
 // GetSubmission retrieves a feedback submission
+//
+// Deprecated: prefer GetSubmissionTyped.
 func (r *FeedbackResource) GetSubmission(submissionID string) (map[string]interface{}, error) {
-	return r.Client.Get(r.GetEndpoint("submissions/"+submissionID), nil)
+	return r.Client.Get(r.GetEndpoint("submissions")+"/"+url.PathEscape(submissionID), nil)
 }
 
 // Client is the main client for the API
 type Client struct {
-	ApiClient    *ApiClient
-	AccessTiers  *AccessTierResource
-	ApiKeys      *ApiKeyResource
-	Users        *UserResource
-	Sandbox      *SandboxResource
-	Feedback     *FeedbackResource
+	ApiClient   *ApiClient
+	AccessTiers *AccessTierResource
+	ApiKeys     *ApiKeyResource
+	Users       *UserResource
+	Sandbox     *SandboxResource
+	Feedback    *FeedbackResource
 }
 
 // NewClient creates a new API client
-func NewClient(apiKey string, baseURL string, config map[string]interface{}) *Client {
-	apiClient := NewApiClient(apiKey, baseURL, config)
-	
-	return &Client{
-		ApiClient:    apiClient,
-		AccessTiers:  NewAccessTierResource(apiClient),
-		ApiKeys:      NewApiKeyResource(apiClient),
-		Users:        NewUserResource(apiClient),
-		Sandbox:      NewSandboxResource(apiClient),
-		Feedback:     NewFeedbackResource(apiClient),
+func NewClient(opts ...Option) (*Client, error) {
+	apiClient, err := NewApiClient(opts...)
+	if err != nil {
+		return nil, err
 	}
+
+	return &Client{
+		ApiClient:   apiClient,
+		AccessTiers: NewAccessTierResource(apiClient),
+		ApiKeys:     NewApiKeyResource(apiClient),
+		Users:       NewUserResource(apiClient),
+		Sandbox:     NewSandboxResource(apiClient),
+		Feedback:    NewFeedbackResource(apiClient),
+	}, nil
 }
 
 // SetApiKey sets the API key for authentication
@@ -0,0 +1,36 @@
+package client
+
+import (
+	"fmt"
+	"log"
+	"log/slog"
+)
+
+// Logger receives diagnostic messages from ApiClient in place of fmt.Printf.
+// Implementations must be safe for concurrent use.
+type Logger interface {
+	Printf(format string, args ...interface{})
+}
+
+// noopLogger discards all messages. It is the default Logger when none is
+// configured via WithLogger.
+type noopLogger struct{}
+
+func (noopLogger) Printf(string, ...interface{}) {}
+
+// StdLogger adapts the standard library *log.Logger to the Logger interface.
+type StdLogger struct {
+	*log.Logger
+}
+
+// SlogLogger adapts a structured *slog.Logger to the Logger interface, for
+// callers who want ApiClient's diagnostics to flow through slog instead of
+// the standard logger. Install it with WithLogger(SlogLogger{Logger: l}).
+type SlogLogger struct {
+	Logger *slog.Logger
+}
+
+// Printf formats its arguments and logs the result at info level.
+func (l SlogLogger) Printf(format string, args ...interface{}) {
+	l.Logger.Info(fmt.Sprintf(format, args...))
+}
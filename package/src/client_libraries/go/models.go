@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"iter"
+	"net/http"
+	"net/url"
+)
+
+// AccessTier is the typed representation of an access tier.
+type AccessTier struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// QuotaUsage is the typed representation of a user's quota usage.
+type QuotaUsage struct {
+	Used  int64 `json:"used"`
+	Limit int64 `json:"limit"`
+}
+
+// UpgradeStatus is the typed representation of a tier upgrade request.
+type UpgradeStatus struct {
+	RequestID string `json:"request_id"`
+	Status    string `json:"status"`
+	TierID    string `json:"tier_id"`
+}
+
+// GetCurrentTyped retrieves the current user's access tier.
+func (r *AccessTierResource) GetCurrentTyped(ctx context.Context) (*Response[AccessTier], error) {
+	return Do[AccessTier](r.Client, ctx, &Request{Method: http.MethodGet, Endpoint: r.GetEndpoint("current")})
+}
+
+// RequestUpgradeTyped requests an upgrade to a new tier.
+func (r *AccessTierResource) RequestUpgradeTyped(ctx context.Context, tierID string) (*Response[UpgradeStatus], error) {
+	return Do[UpgradeStatus](r.Client, ctx, &Request{
+		Method:   http.MethodPost,
+		Endpoint: r.GetEndpoint("request-upgrade"),
+		Body:     map[string]interface{}{"tier_id": tierID},
+	})
+}
+
+// GetUpgradeStatusTyped retrieves the status of a tier upgrade request.
+func (r *AccessTierResource) GetUpgradeStatusTyped(ctx context.Context, requestID string) (*Response[UpgradeStatus], error) {
+	return Do[UpgradeStatus](r.Client, ctx, &Request{Method: http.MethodGet, Endpoint: r.GetEndpoint("upgrade-status") + "/" + url.PathEscape(requestID)})
+}
+
+// GetQuotaUsageTyped retrieves the current user's quota usage.
+func (r *AccessTierResource) GetQuotaUsageTyped(ctx context.Context) (*Response[QuotaUsage], error) {
+	return Do[QuotaUsage](r.Client, ctx, &Request{Method: http.MethodGet, Endpoint: r.GetEndpoint("quota-usage")})
+}
+
+// ApiKey is the typed representation of an API key.
+type ApiKey struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// CreateKeyTyped creates a new API key.
+func (r *ApiKeyResource) CreateKeyTyped(ctx context.Context, name string, expiryDays int) (*Response[ApiKey], error) {
+	data := make(map[string]interface{})
+	if name != "" {
+		data["name"] = name
+	}
+	if expiryDays > 0 {
+		data["expiry_days"] = expiryDays
+	}
+	return Do[ApiKey](r.Client, ctx, &Request{Method: http.MethodPost, Endpoint: r.GetEndpoint(""), Body: data})
+}
+
+// RevokeKeyTyped revokes an API key.
+func (r *ApiKeyResource) RevokeKeyTyped(ctx context.Context, keyID string) (*Response[ApiKey], error) {
+	return Do[ApiKey](r.Client, ctx, &Request{Method: http.MethodDelete, Endpoint: r.GetEndpoint(url.PathEscape(keyID))})
+}
+
+// GetCurrentTyped retrieves the current API key information.
+func (r *ApiKeyResource) GetCurrentTyped(ctx context.Context) (*Response[ApiKey], error) {
+	return Do[ApiKey](r.Client, ctx, &Request{Method: http.MethodGet, Endpoint: r.GetEndpoint("current")})
+}
+
+// UserProfile is the typed representation of a user's profile.
+type UserProfile struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// UserPreferences is the typed representation of a user's preferences.
+type UserPreferences struct {
+	Preferences map[string]interface{} `json:"preferences"`
+}
+
+// GetProfileTyped retrieves the current user's profile.
+func (r *UserResource) GetProfileTyped(ctx context.Context) (*Response[UserProfile], error) {
+	return Do[UserProfile](r.Client, ctx, &Request{Method: http.MethodGet, Endpoint: r.GetEndpoint("profile")})
+}
+
+// UpdateProfileTyped updates the current user's profile.
+func (r *UserResource) UpdateProfileTyped(ctx context.Context, data map[string]interface{}) (*Response[UserProfile], error) {
+	return Do[UserProfile](r.Client, ctx, &Request{Method: http.MethodPut, Endpoint: r.GetEndpoint("profile"), Body: data})
+}
+
+// GetPreferencesTyped retrieves the current user's preferences.
+func (r *UserResource) GetPreferencesTyped(ctx context.Context) (*Response[UserPreferences], error) {
+	return Do[UserPreferences](r.Client, ctx, &Request{Method: http.MethodGet, Endpoint: r.GetEndpoint("preferences")})
+}
+
+// UpdatePreferencesTyped updates the current user's preferences.
+func (r *UserResource) UpdatePreferencesTyped(ctx context.Context, data map[string]interface{}) (*Response[UserPreferences], error) {
+	return Do[UserPreferences](r.Client, ctx, &Request{Method: http.MethodPut, Endpoint: r.GetEndpoint("preferences"), Body: data})
+}
+
+// SandboxEnvironment is the typed representation of a sandbox environment.
+type SandboxEnvironment struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Template string `json:"template"`
+	Status   string `json:"status"`
+}
+
+// CreateEnvironmentTyped creates a new sandbox environment.
+func (r *SandboxResource) CreateEnvironmentTyped(ctx context.Context, name string, template string) (*Response[SandboxEnvironment], error) {
+	data := make(map[string]interface{})
+	if name != "" {
+		data["name"] = name
+	}
+	if template != "" {
+		data["template"] = template
+	}
+	return Do[SandboxEnvironment](r.Client, ctx, &Request{Method: http.MethodPost, Endpoint: r.GetEndpoint("environments"), Body: data})
+}
+
+// GetEnvironmentTyped retrieves a sandbox environment.
+func (r *SandboxResource) GetEnvironmentTyped(ctx context.Context, environmentID string) (*Response[SandboxEnvironment], error) {
+	return Do[SandboxEnvironment](r.Client, ctx, &Request{Method: http.MethodGet, Endpoint: r.GetEndpoint("environments") + "/" + url.PathEscape(environmentID)})
+}
+
+// DeleteEnvironmentTyped deletes a sandbox environment.
+func (r *SandboxResource) DeleteEnvironmentTyped(ctx context.Context, environmentID string) (*Response[SandboxEnvironment], error) {
+	return Do[SandboxEnvironment](r.Client, ctx, &Request{Method: http.MethodDelete, Endpoint: r.GetEndpoint("environments") + "/" + url.PathEscape(environmentID)})
+}
+
+// ExecuteRequestTyped executes a request in the sandbox environment.
+func (r *SandboxResource) ExecuteRequestTyped(ctx context.Context, environmentID string, endpoint string, method string, data map[string]interface{}) (*Response[map[string]interface{}], error) {
+	requestData := map[string]interface{}{
+		"endpoint": endpoint,
+		"method":   method,
+	}
+	if data != nil {
+		requestData["data"] = data
+	}
+	return Do[map[string]interface{}](r.Client, ctx, &Request{
+		Method:   http.MethodPost,
+		Endpoint: r.GetEndpoint("environments") + "/" + url.PathEscape(environmentID) + "/execute",
+		Body:     requestData,
+	})
+}
+
+// Feedback is the typed representation of a feedback submission.
+type Feedback struct {
+	ID       string                 `json:"id"`
+	Type     string                 `json:"type"`
+	Content  string                 `json:"content"`
+	Metadata map[string]interface{} `json:"metadata"`
+}
+
+// SubmitTyped submits feedback.
+func (r *FeedbackResource) SubmitTyped(ctx context.Context, feedbackType string, content string, metadata map[string]interface{}) (*Response[Feedback], error) {
+	data := map[string]interface{}{
+		"type":    feedbackType,
+		"content": content,
+	}
+	if metadata != nil {
+		data["metadata"] = metadata
+	}
+	return Do[Feedback](r.Client, ctx, &Request{Method: http.MethodPost, Endpoint: r.GetEndpoint(""), Body: data})
+}
+
+// GetSubmissionsTyped returns an iterator over the current user's feedback submissions.
+func (r *FeedbackResource) GetSubmissionsTyped(ctx context.Context) iter.Seq2[Feedback, error] {
+	return List[Feedback](ctx, r.Client, r.GetEndpoint("submissions"), nil)
+}
+
+// GetSubmissionTyped retrieves a feedback submission.
+func (r *FeedbackResource) GetSubmissionTyped(ctx context.Context, submissionID string) (*Response[Feedback], error) {
+	return Do[Feedback](r.Client, ctx, &Request{Method: http.MethodGet, Endpoint: r.GetEndpoint("submissions") + "/" + url.PathEscape(submissionID)})
+}
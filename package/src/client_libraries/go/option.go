@@ -0,0 +1,106 @@
+package client
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+)
+
+// Option configures an ApiClient constructed by NewApiClient.
+type Option func(*ApiClient)
+
+// WithAPIKey sets the bearer token used to authenticate requests. If unset,
+// NewApiClient falls back to the API_KEY environment variable.
+func WithAPIKey(apiKey string) Option {
+	return func(c *ApiClient) { c.ApiKey = apiKey }
+}
+
+// WithBaseURL overrides the API base URL. If unset, NewApiClient falls back
+// to the API_BASE_URL environment variable and then a built-in default.
+func WithBaseURL(baseURL string) Option {
+	return func(c *ApiClient) { c.BaseURL = baseURL }
+}
+
+// WithHTTPClient replaces the underlying *http.Client used for all requests.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *ApiClient) { c.HttpClient = hc }
+}
+
+// WithHeader sets a default header sent with every request.
+func WithHeader(key, value string) Option {
+	return func(c *ApiClient) { c.Headers[key] = value }
+}
+
+// WithRetryPolicy overrides the default retry and backoff behavior.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *ApiClient) { c.RetryPolicy = p }
+}
+
+// WithRetryNonIdempotent allows retrying verbs other than GET/HEAD/PUT/DELETE/OPTIONS.
+func WithRetryNonIdempotent() Option {
+	return func(c *ApiClient) { c.RetryNonIdempotent = true }
+}
+
+// WithRetryDecision overrides the default retry-on-status-code behavior for a
+// specific HTTP status.
+func WithRetryDecision(status int, retry bool) Option {
+	return func(c *ApiClient) { c.retryDecisions[status] = retry }
+}
+
+// WithRateLimiter installs a custom rate limiter. Pass
+// rate.NewLimiter(rate.Inf, 0) to disable client-side rate limiting entirely.
+func WithRateLimiter(l *rate.Limiter) Option {
+	return func(c *ApiClient) { c.Limiter = l }
+}
+
+// WithRateLimit configures the default limiter to allow rps requests per
+// second with the given burst.
+func WithRateLimit(rps float64, burst int) Option {
+	return func(c *ApiClient) { c.Limiter = rate.NewLimiter(rate.Limit(rps), burst) }
+}
+
+// WithLogger installs a Logger for diagnostic output.
+func WithLogger(l Logger) Option {
+	return func(c *ApiClient) { c.Logger = l }
+}
+
+// WithAuthenticator installs the Authenticator used to sign every outgoing
+// request, overriding the BearerToken authenticator NewApiClient installs
+// from ApiKey/WithAPIKey.
+func WithAuthenticator(a Authenticator) Option {
+	return func(c *ApiClient) { c.Authenticator = a }
+}
+
+// WithRoundTripper wraps the client's transport with mw. Middleware is
+// applied in the order the options are given, with the first option
+// becoming the outermost layer.
+func WithRoundTripper(mw func(http.RoundTripper) http.RoundTripper) Option {
+	return func(c *ApiClient) { c.transportMiddleware = append(c.transportMiddleware, mw) }
+}
+
+// WithTracerProvider installs the trace.TracerProvider used to create spans
+// for outbound requests. If unset, NewApiClient falls back to
+// otel.GetTracerProvider().
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *ApiClient) { c.TracerProvider = tp }
+}
+
+// WithMeterProvider installs the metric.MeterProvider used to record the
+// apiclient.request.* instruments. If unset, NewApiClient falls back to
+// otel.GetMeterProvider().
+func WithMeterProvider(mp metric.MeterProvider) Option {
+	return func(c *ApiClient) { c.MeterProvider = mp }
+}
+
+// WithRequestHook installs a callback invoked with the outgoing request
+// immediately before it is sent, once per retry attempt.
+func WithRequestHook(h RequestHook) Option {
+	return func(c *ApiClient) { c.RequestHook = h }
+}
+
+// WithResponseHook installs a callback invoked after each attempt completes.
+func WithResponseHook(h ResponseHook) Option {
+	return func(c *ApiClient) { c.ResponseHook = h }
+}
@@ -0,0 +1,151 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies this package to OTEL as the source of its
+// spans and instruments.
+const instrumentationName = "github.com/wesheets/promethios/package/src/client_libraries/go"
+
+// RequestHook is called with the outgoing *http.Request immediately before it
+// is sent, once per retry attempt. It is a lighter-weight alternative to
+// OTEL/slog for callers who just want to plug in their own logging.
+type RequestHook func(ctx context.Context, req *http.Request)
+
+// ResponseHook is called after each attempt completes, with the resp and err
+// returned by http.Client.Do: normally exactly one of them is non-nil, but
+// both are when a redirect is refused by the client's CheckRedirect.
+type ResponseHook func(ctx context.Context, resp *http.Response, err error)
+
+// instruments holds the OTEL tracer and metric instruments built once by
+// NewApiClient from TracerProvider/MeterProvider.
+type instruments struct {
+	tracer          trace.Tracer
+	requestDuration metric.Float64Histogram
+	requestRetries  metric.Int64Counter
+	requestErrors   metric.Int64Counter
+}
+
+// newInstruments builds the tracer and instruments used by do(). A nil tp/mp
+// falls back to the global OTEL providers, so instrumentation is a no-op
+// until the application configures one.
+func newInstruments(tp trace.TracerProvider, mp metric.MeterProvider) (*instruments, error) {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	meter := mp.Meter(instrumentationName)
+
+	duration, err := meter.Float64Histogram(
+		"apiclient.request.duration",
+		metric.WithDescription("Duration of outbound API requests, in seconds"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	retries, err := meter.Int64Counter(
+		"apiclient.request.retries",
+		metric.WithDescription("Number of request attempts retried"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	requestErrors, err := meter.Int64Counter(
+		"apiclient.request.errors",
+		metric.WithDescription("Number of requests that ended in a 4xx/5xx response or transport error"),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &instruments{
+		tracer:          tp.Tracer(instrumentationName),
+		requestDuration: duration,
+		requestRetries:  retries,
+		requestErrors:   requestErrors,
+	}, nil
+}
+
+// statusClass buckets an HTTP status code into "2xx"/"4xx"/etc, or "unknown"
+// when no response was received (statusCode <= 0).
+func statusClass(statusCode int) string {
+	if statusCode <= 0 {
+		return "unknown"
+	}
+	return strconv.Itoa(statusCode/100) + "xx"
+}
+
+// staticRouteSegments is the fixed vocabulary of literal path segments used
+// across this client's endpoints. Everything else is a variable resource
+// identifier (a user ID, sandbox environment ID, submission ID, ...).
+var staticRouteSegments = map[string]bool{
+	"access-tiers": true, "request-upgrade": true, "upgrade-status": true, "quota-usage": true,
+	"api-keys": true, "current": true,
+	"users": true, "profile": true, "preferences": true,
+	"sandbox": true, "environments": true, "execute": true, "artifact": true,
+	"feedback": true, "submissions": true,
+}
+
+// routeTemplate collapses endpoint's variable segments to "{id}", producing
+// a low-cardinality route pattern (e.g. "environments/{id}/artifact") safe
+// to use as a span name or metric label. The fully-interpolated endpoint
+// still has its place as a span attribute for debugging a single trace.
+func routeTemplate(endpoint string) string {
+	segments := strings.Split(strings.Trim(endpoint, "/"), "/")
+	for i, seg := range segments {
+		if seg == "" || staticRouteSegments[seg] {
+			continue
+		}
+		segments[i] = "{id}"
+	}
+	return strings.Join(segments, "/")
+}
+
+// injectTraceContext propagates ctx's span as a W3C traceparent header onto
+// req, so the receiving service can join the same trace.
+func injectTraceContext(ctx context.Context, req *http.Request) {
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+}
+
+// spanAttributes builds the common set of span attributes recorded once a
+// request completes (or fails outright). http.route is the low-cardinality
+// route template; url.path carries the fully-interpolated endpoint for
+// debugging a single trace without polluting span names or metric labels.
+func spanAttributes(method, route, endpoint string, statusCode, attempt, responseSize int) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("http.method", method),
+		attribute.String("http.route", route),
+		attribute.String("url.path", endpoint),
+		attribute.Int("http.status_code", statusCode),
+		attribute.Int("apiclient.retry_count", attempt),
+		attribute.Int("http.response_content_length", responseSize),
+	}
+}
+
+// spanStatusFor reports the OTEL span status for a completed attempt.
+func spanStatusFor(statusCode int, err error) (codes.Code, string) {
+	if err != nil {
+		return codes.Error, err.Error()
+	}
+	if statusCode >= 400 {
+		return codes.Error, http.StatusText(statusCode)
+	}
+	return codes.Unset, ""
+}
@@ -0,0 +1,105 @@
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Params holds query parameters with typed setters, for use with the typed
+// Do/List API in place of map[string]interface{}. The zero value is an
+// empty Params ready to use.
+type Params struct {
+	values url.Values
+}
+
+// NewParams returns an empty Params.
+func NewParams() *Params {
+	return &Params{values: url.Values{}}
+}
+
+func (p *Params) init() {
+	if p.values == nil {
+		p.values = url.Values{}
+	}
+}
+
+// Set sets a string parameter, replacing any existing value(s).
+func (p *Params) Set(key, value string) *Params {
+	p.init()
+	p.values.Set(key, value)
+	return p
+}
+
+// SetInt sets an integer parameter.
+func (p *Params) SetInt(key string, value int) *Params {
+	p.init()
+	p.values.Set(key, strconv.Itoa(value))
+	return p
+}
+
+// SetBool sets a boolean parameter.
+func (p *Params) SetBool(key string, value bool) *Params {
+	p.init()
+	p.values.Set(key, strconv.FormatBool(value))
+	return p
+}
+
+// SetTime sets a time parameter encoded as RFC3339.
+func (p *Params) SetTime(key string, value time.Time) *Params {
+	p.init()
+	p.values.Set(key, value.Format(time.RFC3339))
+	return p
+}
+
+// SetStrings sets a repeated string parameter (multiple values for the same key).
+func (p *Params) SetStrings(key string, values []string) *Params {
+	p.init()
+	p.values[key] = append([]string(nil), values...)
+	return p
+}
+
+// SetInts sets a repeated integer parameter.
+func (p *Params) SetInts(key string, values []int) *Params {
+	p.init()
+	strs := make([]string, len(values))
+	for i, v := range values {
+		strs[i] = strconv.Itoa(v)
+	}
+	p.values[key] = strs
+	return p
+}
+
+// Values returns the underlying url.Values, or nil if p is nil.
+func (p *Params) Values() url.Values {
+	if p == nil {
+		return nil
+	}
+	return p.values
+}
+
+// paramsFromMap converts the deprecated map[string]interface{} param shape
+// used by Request/Get/Resource.List etc. into url.Values, expanding
+// []string/[]int values into repeated keys.
+func paramsFromMap(m map[string]interface{}) url.Values {
+	if len(m) == 0 {
+		return nil
+	}
+	values := url.Values{}
+	for k, v := range m {
+		switch vv := v.(type) {
+		case []string:
+			for _, s := range vv {
+				values.Add(k, s)
+			}
+		case []int:
+			for _, n := range vv {
+				values.Add(k, strconv.Itoa(n))
+			}
+		default:
+			values.Add(k, fmt.Sprintf("%v", v))
+		}
+	}
+	return values
+}
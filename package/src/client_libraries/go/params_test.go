@@ -0,0 +1,43 @@
+package client
+
+import "testing"
+
+func TestParamsEncodeSortedAndEscaped(t *testing.T) {
+	p := NewParams().
+		Set("b", "hello world").
+		Set("a", "x&y=z").
+		SetInt("c", 7)
+
+	got := p.Values().Encode()
+	want := "a=x%26y%3Dz&b=hello+world&c=7"
+	if got != want {
+		t.Errorf("Encode() = %q, want %q", got, want)
+	}
+}
+
+func TestParamsFromMapExpandsSlices(t *testing.T) {
+	values := paramsFromMap(map[string]interface{}{
+		"tags": []string{"a", "b"},
+		"ids":  []int{1, 2},
+		"name": "widget",
+	})
+
+	if got := values["tags"]; len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("tags = %v, want [a b]", got)
+	}
+	if got := values["ids"]; len(got) != 2 || got[0] != "1" || got[1] != "2" {
+		t.Errorf("ids = %v, want [1 2]", got)
+	}
+	if got := values.Get("name"); got != "widget" {
+		t.Errorf("name = %q, want %q", got, "widget")
+	}
+}
+
+func TestParamsFromMapEmpty(t *testing.T) {
+	if values := paramsFromMap(nil); values != nil {
+		t.Errorf("paramsFromMap(nil) = %v, want nil", values)
+	}
+	if values := paramsFromMap(map[string]interface{}{}); values != nil {
+		t.Errorf("paramsFromMap({}) = %v, want nil", values)
+	}
+}
@@ -0,0 +1,72 @@
+package client
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how ApiClient.Request backs off between retries.
+type RetryPolicy struct {
+	MaxRetries    int
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+	Backoff       float64
+}
+
+// DefaultRetryPolicy is used by NewApiClient when no WithRetryPolicy option is given.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxRetries:    3,
+	MinRetryDelay: time.Second,
+	MaxRetryDelay: 30 * time.Second,
+	Backoff:       2.0,
+}
+
+// delay returns the exponential backoff before the given retry attempt
+// (1-indexed), clamped to [MinRetryDelay, MaxRetryDelay].
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := time.Duration(float64(p.MinRetryDelay) * math.Pow(p.Backoff, float64(attempt-1)))
+	if d < p.MinRetryDelay {
+		d = p.MinRetryDelay
+	}
+	if d > p.MaxRetryDelay {
+		d = p.MaxRetryDelay
+	}
+	return d
+}
+
+// idempotentMethods are retried by default; other verbs require
+// ApiClient.RetryNonIdempotent (or WithRetryNonIdempotent) to be retried.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+	http.MethodOptions: true,
+}
+
+// shouldRetryStatus reports the default retry decision for a response status
+// code, before any per-status override registered via WithRetryDecision.
+func shouldRetryStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// retryAfter parses a Retry-After header in either delta-seconds or HTTP-date
+// form, as allowed by RFC 7231. ok is false if the header is empty or
+// unparseable.
+func retryAfter(header string) (d time.Duration, ok bool) {
+	if header == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(header); err == nil {
+		if d := time.Until(t); d > 0 {
+			return d, true
+		}
+		return 0, true
+	}
+	return 0, false
+}
@@ -0,0 +1,70 @@
+package client
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{
+		MinRetryDelay: time.Second,
+		MaxRetryDelay: 10 * time.Second,
+		Backoff:       2.0,
+	}
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 3, want: 4 * time.Second},
+		{attempt: 4, want: 8 * time.Second},
+		{attempt: 5, want: 10 * time.Second}, // clamped to MaxRetryDelay
+		{attempt: 0, want: time.Second},      // clamped up to MinRetryDelay
+		{attempt: -1, want: time.Second},     // clamped up to MinRetryDelay
+	}
+
+	for _, tt := range tests {
+		if got := p.delay(tt.attempt); got != tt.want {
+			t.Errorf("delay(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		header  string
+		wantOK  bool
+		wantMin time.Duration
+	}{
+		{name: "empty", header: "", wantOK: false},
+		{name: "malformed", header: "not-a-valid-value", wantOK: false},
+		{name: "delta seconds", header: "120", wantOK: true, wantMin: 120 * time.Second},
+		{name: "HTTP date in the past", header: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), wantOK: true, wantMin: 0},
+	}
+
+	for _, tt := range tests {
+		d, ok := retryAfter(tt.header)
+		if ok != tt.wantOK {
+			t.Errorf("%s: retryAfter(%q) ok = %v, want %v", tt.name, tt.header, ok, tt.wantOK)
+			continue
+		}
+		if ok && tt.name == "delta seconds" && d != tt.wantMin {
+			t.Errorf("%s: retryAfter(%q) = %v, want %v", tt.name, tt.header, d, tt.wantMin)
+		}
+	}
+}
+
+func TestRetryAfterHTTPDate(t *testing.T) {
+	future := time.Now().Add(2 * time.Minute).UTC()
+	d, ok := retryAfter(future.Format(http.TimeFormat))
+	if !ok {
+		t.Fatalf("retryAfter(%q) ok = false, want true", future.Format(http.TimeFormat))
+	}
+	if d <= 0 || d > 2*time.Minute {
+		t.Errorf("retryAfter(%q) = %v, want roughly 2m", future.Format(http.TimeFormat), d)
+	}
+}
@@ -0,0 +1,277 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	defaultBlockSize   = 4 * 1024 * 1024
+	defaultParallelism = 5
+)
+
+// RequestOption configures a single call to RequestStream.
+type RequestOption func(*http.Request)
+
+// WithRequestHeader sets a header on the outgoing *http.Request.
+func WithRequestHeader(key, value string) RequestOption {
+	return func(req *http.Request) { req.Header.Set(key, value) }
+}
+
+// RequestStream sends method/endpoint and returns the raw *http.Response
+// without buffering or parsing the body, for streaming large
+// uploads/downloads. The caller is responsible for closing resp.Body.
+// Authentication, default headers, and the client's rate limiter are
+// applied, but RequestStream does not retry: a body already partially read
+// by a failed attempt can't be safely replayed. Like do, it is wrapped in a
+// span, propagates a traceparent header, and invokes RequestHook/ResponseHook.
+func (c *ApiClient) RequestStream(ctx context.Context, method string, endpoint string, body io.Reader, opts ...RequestOption) (resp *http.Response, err error) {
+	start := time.Now()
+	route := routeTemplate(endpoint)
+	ctx, span := c.instr.tracer.Start(ctx, fmt.Sprintf("HTTP %s %s", method, route), trace.WithSpanKind(trace.SpanKindClient))
+	lastStatus := 0
+	defer func() {
+		statusCode := lastStatus
+
+		span.SetAttributes(spanAttributes(method, route, endpoint, statusCode, 0, 0)...)
+		statusCodeField, desc := spanStatusFor(statusCode, err)
+		span.SetStatus(statusCodeField, desc)
+		span.End()
+
+		metricAttrs := metric.WithAttributes(
+			attribute.String("endpoint", route),
+			attribute.String("status_class", statusClass(statusCode)),
+		)
+		c.instr.requestDuration.Record(ctx, time.Since(start).Seconds(), metricAttrs)
+		if err != nil || statusCode >= 400 {
+			c.instr.requestErrors.Add(ctx, 1, metricAttrs)
+		}
+	}()
+
+	url := c.BaseURL + "/" + trimPrefix(endpoint, "/")
+
+	req, reqErr := http.NewRequestWithContext(ctx, method, url, body)
+	if reqErr != nil {
+		err = fmt.Errorf("error creating request: %w", reqErr)
+		return nil, err
+	}
+
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+	injectTraceContext(ctx, req)
+
+	if waitErr := c.Limiter.Wait(ctx); waitErr != nil {
+		err = fmt.Errorf("rate limiter: %w", waitErr)
+		return nil, err
+	}
+
+	if c.Authenticator != nil {
+		if authErr := c.Authenticator.Apply(req); authErr != nil {
+			err = fmt.Errorf("applying authenticator: %w", authErr)
+			return nil, err
+		}
+	}
+
+	if c.RequestHook != nil {
+		c.RequestHook(ctx, req)
+	}
+
+	resp, err = c.HttpClient.Do(req)
+	if c.ResponseHook != nil {
+		c.ResponseHook(ctx, resp, err)
+	}
+	if err != nil {
+		err = fmt.Errorf("streaming request failed: %w", err)
+		return nil, err
+	}
+	lastStatus = resp.StatusCode
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+		err = &ApiError{Message: string(errBody), Code: resp.StatusCode}
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// DownloadOptions configures DownloadToWriterAt.
+type DownloadOptions struct {
+	// BlockSize is the size of each ranged request. Defaults to 4 MiB.
+	BlockSize int64
+	// Parallelism is the number of concurrent range requests. Defaults to 5.
+	Parallelism int
+}
+
+// DownloadToWriterAt downloads endpoint in parallel opts.BlockSize-sized
+// HTTP Range requests, writing each chunk to its computed offset in w via an
+// errgroup so any chunk's failure cancels the rest. It first probes the
+// resource with a HEAD request to learn Content-Length; servers that don't
+// support HEAD or byte ranges should use RequestStream directly instead.
+func (c *ApiClient) DownloadToWriterAt(ctx context.Context, endpoint string, w io.WriterAt, opts DownloadOptions) error {
+	blockSize := opts.BlockSize
+	if blockSize <= 0 {
+		blockSize = defaultBlockSize
+	}
+	parallelism := opts.Parallelism
+	if parallelism <= 0 {
+		parallelism = defaultParallelism
+	}
+
+	size, err := c.contentLength(ctx, endpoint)
+	if err != nil {
+		return err
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(parallelism)
+
+	for offset := int64(0); offset < size; offset += blockSize {
+		offset := offset
+		end := offset + blockSize - 1
+		if end >= size {
+			end = size - 1
+		}
+		g.Go(func() error {
+			return c.downloadRange(gctx, endpoint, w, offset, end)
+		})
+	}
+
+	return g.Wait()
+}
+
+func (c *ApiClient) contentLength(ctx context.Context, endpoint string) (int64, error) {
+	url := c.BaseURL + "/" + trimPrefix(endpoint, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, fmt.Errorf("error creating HEAD request: %w", err)
+	}
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+	if err := c.Limiter.Wait(ctx); err != nil {
+		return 0, fmt.Errorf("rate limiter: %w", err)
+	}
+	if c.Authenticator != nil {
+		if err := c.Authenticator.Apply(req); err != nil {
+			return 0, fmt.Errorf("applying authenticator: %w", err)
+		}
+	}
+
+	resp, err := c.HttpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("error probing content length: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return 0, fmt.Errorf("probing content length: server returned status %d for %s", resp.StatusCode, endpoint)
+	}
+	if resp.ContentLength < 0 {
+		return 0, fmt.Errorf("server did not report Content-Length for %s", endpoint)
+	}
+	return resp.ContentLength, nil
+}
+
+func (c *ApiClient) downloadRange(ctx context.Context, endpoint string, w io.WriterAt, start, end int64) error {
+	resp, err := c.RequestStream(ctx, http.MethodGet, endpoint, nil,
+		WithRequestHeader("Range", fmt.Sprintf("bytes=%d-%d", start, end)))
+	if err != nil {
+		return fmt.Errorf("error downloading range %d-%d: %w", start, end, err)
+	}
+	defer resp.Body.Close()
+
+	if _, err := io.Copy(io.NewOffsetWriter(w, start), resp.Body); err != nil {
+		return fmt.Errorf("error writing range %d-%d: %w", start, end, err)
+	}
+	return nil
+}
+
+// UploadOptions configures UploadFromReader.
+type UploadOptions struct {
+	// ContentLength must be set to split r into chunks; otherwise the whole
+	// reader is sent as a single streamed PUT.
+	ContentLength int64
+	// BlockSize is the size of each chunk. Defaults to 4 MiB.
+	BlockSize int64
+	// Parallelism is the number of concurrent chunk uploads. A value <= 1
+	// uploads chunks sequentially. Parallel uploads require r to implement
+	// io.ReaderAt.
+	Parallelism int
+}
+
+// UploadFromReader uploads r to endpoint. When opts.ContentLength is known,
+// r is split into opts.BlockSize chunks and PUT individually, each carrying
+// a Content-Range header, sequentially or with opts.Parallelism concurrent
+// uploads coordinated by an errgroup. Otherwise the whole reader is sent as
+// a single streamed PUT.
+func (c *ApiClient) UploadFromReader(ctx context.Context, endpoint string, r io.Reader, opts UploadOptions) error {
+	if opts.ContentLength <= 0 {
+		resp, err := c.RequestStream(ctx, http.MethodPut, endpoint, r)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	blockSize := opts.BlockSize
+	if blockSize <= 0 {
+		blockSize = defaultBlockSize
+	}
+
+	if opts.Parallelism <= 1 {
+		for offset := int64(0); offset < opts.ContentLength; offset += blockSize {
+			n := blockSize
+			if offset+n > opts.ContentLength {
+				n = opts.ContentLength - offset
+			}
+			if err := c.uploadChunk(ctx, endpoint, io.LimitReader(r, n), offset, offset+n-1, opts.ContentLength); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	ra, ok := r.(io.ReaderAt)
+	if !ok {
+		return fmt.Errorf("parallel upload requires an io.ReaderAt source")
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(opts.Parallelism)
+
+	for offset := int64(0); offset < opts.ContentLength; offset += blockSize {
+		offset := offset
+		n := blockSize
+		if offset+n > opts.ContentLength {
+			n = opts.ContentLength - offset
+		}
+		g.Go(func() error {
+			return c.uploadChunk(gctx, endpoint, io.NewSectionReader(ra, offset, n), offset, offset+n-1, opts.ContentLength)
+		})
+	}
+
+	return g.Wait()
+}
+
+func (c *ApiClient) uploadChunk(ctx context.Context, endpoint string, r io.Reader, start, end, total int64) error {
+	resp, err := c.RequestStream(ctx, http.MethodPut, endpoint, r,
+		WithRequestHeader("Content-Range", fmt.Sprintf("bytes %d-%d/%d", start, end, total)))
+	if err != nil {
+		return fmt.Errorf("error uploading chunk %d-%d: %w", start, end, err)
+	}
+	return resp.Body.Close()
+}
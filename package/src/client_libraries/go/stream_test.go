@@ -0,0 +1,125 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"golang.org/x/time/rate"
+)
+
+func newStreamTestClient(t *testing.T, baseURL string) *ApiClient {
+	t.Helper()
+	c, err := NewApiClient(
+		WithBaseURL(baseURL),
+		WithRateLimiter(rate.NewLimiter(rate.Inf, 0)),
+	)
+	if err != nil {
+		t.Fatalf("NewApiClient: %v", err)
+	}
+	return c
+}
+
+// bufferWriterAt is a concurrency-safe io.WriterAt backed by a fixed-size
+// byte slice, for asserting that parallel range writes land at the right
+// offsets.
+type bufferWriterAt struct {
+	mu  sync.Mutex
+	buf []byte
+}
+
+func (w *bufferWriterAt) WriteAt(p []byte, off int64) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	n := copy(w.buf[off:], p)
+	return n, nil
+}
+
+func TestDownloadToWriterAtLandsBytesAtOffset(t *testing.T) {
+	content := bytes.Repeat([]byte("0123456789"), 1000) // 10000 bytes
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead {
+			w.Header().Set("Content-Length", fmt.Sprintf("%d", len(content)))
+			return
+		}
+
+		var start, end int
+		if _, err := fmt.Sscanf(r.Header.Get("Range"), "bytes=%d-%d", &start, &end); err != nil {
+			http.Error(w, "bad range", http.StatusBadRequest)
+			return
+		}
+		if end >= len(content) {
+			end = len(content) - 1
+		}
+		w.Write(content[start : end+1])
+	}))
+	defer srv.Close()
+
+	c := newStreamTestClient(t, srv.URL)
+	dst := &bufferWriterAt{buf: make([]byte, len(content))}
+
+	opts := DownloadOptions{BlockSize: 1500, Parallelism: 4}
+	if err := c.DownloadToWriterAt(context.Background(), "artifact", dst, opts); err != nil {
+		t.Fatalf("DownloadToWriterAt: %v", err)
+	}
+
+	if !bytes.Equal(dst.buf, content) {
+		t.Errorf("downloaded content did not land at the correct offsets")
+	}
+}
+
+func TestDownloadToWriterAtErrorsOnHEADFailure(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "0")
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer srv.Close()
+
+	c := newStreamTestClient(t, srv.URL)
+	dst := &bufferWriterAt{buf: make([]byte, 16)}
+
+	if err := c.DownloadToWriterAt(context.Background(), "missing", dst, DownloadOptions{}); err == nil {
+		t.Fatal("DownloadToWriterAt with a 404 HEAD response: got nil error, want non-nil")
+	}
+}
+
+func TestUploadFromReaderLandsChunksAtOffset(t *testing.T) {
+	const total = 10000
+	content := bytes.Repeat([]byte("abcdefghij"), total/10)
+
+	var mu sync.Mutex
+	received := make([]byte, total)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var start, end, size int
+		if _, err := fmt.Sscanf(r.Header.Get("Content-Range"), "bytes %d-%d/%d", &start, &end, &size); err != nil {
+			http.Error(w, "bad content-range", http.StatusBadRequest)
+			return
+		}
+		body := make([]byte, end-start+1)
+		if _, err := io.ReadFull(r.Body, body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		copy(received[start:end+1], body)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	c := newStreamTestClient(t, srv.URL)
+	opts := UploadOptions{ContentLength: total, BlockSize: 1500, Parallelism: 4}
+	if err := c.UploadFromReader(context.Background(), "artifact", bytes.NewReader(content), opts); err != nil {
+		t.Fatalf("UploadFromReader: %v", err)
+	}
+
+	if !bytes.Equal(received, content) {
+		t.Errorf("uploaded content did not land at the correct offsets")
+	}
+}
@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"net/http"
+)
+
+// Request describes a single typed API call executed with Do.
+type Request struct {
+	Method   string
+	Endpoint string
+	Params   *Params
+	Body     interface{}
+	Headers  map[string]string
+}
+
+// Response is the typed result of a Do call.
+type Response[T any] struct {
+	Data       T
+	RawBody    []byte
+	StatusCode int
+	Headers    http.Header
+}
+
+// Do executes req against c and decodes a successful JSON response body into
+// T. A 4xx/5xx response is returned as an *ApiError, following the same
+// {"error": {"message", "code"}} convention as the untyped Request method.
+func Do[T any](c *ApiClient, ctx context.Context, req *Request) (*Response[T], error) {
+	resp, body, err := c.do(ctx, req.Method, req.Endpoint, req.Params.Values(), req.Body, req.Headers)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= 400 {
+		var errResponse map[string]interface{}
+		if len(body) > 0 {
+			if err := json.Unmarshal(body, &errResponse); err != nil {
+				errResponse = map[string]interface{}{"raw_content": string(body)}
+			}
+		}
+
+		errorMessage := "Unknown error"
+		errorCode := resp.StatusCode
+		if errorData, ok := errResponse["error"].(map[string]interface{}); ok {
+			if msg, ok := errorData["message"].(string); ok {
+				errorMessage = msg
+			}
+			if code, ok := errorData["code"].(float64); ok {
+				errorCode = int(code)
+			}
+		}
+
+		return nil, &ApiError{
+			Message:  errorMessage,
+			Code:     errorCode,
+			Response: errResponse,
+		}
+	}
+
+	var data T
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &data); err != nil {
+			return nil, fmt.Errorf("error decoding response data: %w", err)
+		}
+	}
+
+	return &Response[T]{
+		Data:       data,
+		RawBody:    body,
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+	}, nil
+}
+
+// page is the pagination envelope expected around each item type T.
+type page[T any] struct {
+	Items []T    `json:"items"`
+	Next  string `json:"next"`
+}
+
+// List returns an iterator over every item at endpoint, automatically
+// following the "next" cursor in each page until the API stops returning
+// one. Iteration stops early, surfacing err, if a page request fails.
+func List[T any](ctx context.Context, c *ApiClient, endpoint string, params *Params) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		cursor := ""
+		for {
+			pageParams := &Params{}
+			pageParams.init()
+			for k, v := range params.Values() {
+				pageParams.values[k] = append([]string(nil), v...)
+			}
+			if cursor != "" {
+				pageParams.Set("cursor", cursor)
+			}
+
+			resp, err := Do[page[T]](c, ctx, &Request{
+				Method:   http.MethodGet,
+				Endpoint: endpoint,
+				Params:   pageParams,
+			})
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range resp.Data.Items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			if resp.Data.Next == "" {
+				return
+			}
+			cursor = resp.Data.Next
+		}
+	}
+}